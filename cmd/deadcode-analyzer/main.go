@@ -0,0 +1,14 @@
+// Command deadcode-analyzer runs the conservative per-package deadcode
+// analyzer (pkg/analyzer) as a standalone go/analysis checker, e.g. for
+// use outside golangci-lint's plugin system.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/dev-eyoungmin/dead-code-detector/pkg/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,67 @@
+// Command deadcode-fix deletes the declarations reported dead by the
+// deadcode analyzer directly from their source files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dev-eyoungmin/dead-code-detector/internal/deadcode"
+	"github.com/dev-eyoungmin/dead-code-detector/internal/fix"
+)
+
+func main() {
+	includeExported := flag.Bool("include-exported", false, "also remove exported identifiers of non-main packages")
+	includeTests := flag.Bool("tests", false, "also rewrite _test.go files")
+	diff := flag.Bool("diff", false, "preview changes as a unified diff instead of writing files")
+	configPath := flag.String("config", ".deadcode.yaml", "path to a liveness heuristics config file (see deadcode.Config); missing file is not an error")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deadcode-fix [flags] <packages>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	cfg, err := deadcode.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode-fix:", err)
+		os.Exit(1)
+	}
+
+	findings, err := deadcode.Analyze(patterns, deadcode.Options{IncludeExported: *includeExported, Config: cfg})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode-fix:", err)
+		os.Exit(1)
+	}
+	if len(findings) == 0 {
+		return
+	}
+
+	changes, err := fix.Apply(findings, fix.Options{IncludeTests: *includeTests, Diff: *diff})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode-fix:", err)
+		os.Exit(1)
+	}
+
+	for _, c := range changes {
+		if c.Skipped {
+			fmt.Fprintf(os.Stderr, "deadcode-fix: skipping %s: %s\n", c.Path, c.Reason)
+			continue
+		}
+		if !*diff {
+			fmt.Println("fixed", c.Path)
+			continue
+		}
+		d, err := fix.Diff(c.Path, c.Before, c.After)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "deadcode-fix:", err)
+			os.Exit(1)
+		}
+		fmt.Print(d)
+	}
+}
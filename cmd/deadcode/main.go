@@ -0,0 +1,129 @@
+// Command deadcode reports package-level declarations that are never
+// referenced within the analyzed packages.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/dev-eyoungmin/dead-code-detector/internal/deadcode"
+)
+
+// output controls how findings are rendered, shared by every analysis mode.
+type output struct {
+	json   bool
+	format string
+}
+
+func main() {
+	includeExported := flag.Bool("include-exported", false, "also report exported identifiers of non-main packages")
+	ssaMode := flag.Bool("ssa", false, "use whole-program SSA reachability with interface devirtualization, rather than syntactic def/use analysis")
+	algo := flag.String("algo", "rta", "call-graph algorithm for -ssa: rta or vta")
+	whyLive := flag.String("whyLive", "", "with -ssa, print the shortest call path that makes pkg.Func reachable, instead of reporting findings")
+	jsonOutput := flag.Bool("json", false, "emit findings as a stream of JSON objects grouped by package, see pkg/deadcodejson")
+	format := flag.String("f", "", "format each finding using a text/template, as with 'go list -f'")
+	configPath := flag.String("config", ".deadcode.yaml", "path to a liveness heuristics config file (see Config); missing file is not an error")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: deadcode [flags] <packages>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	out := output{json: *jsonOutput, format: *format}
+
+	cfg, err := deadcode.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode:", err)
+		os.Exit(1)
+	}
+
+	if *whyLive != "" {
+		if !*ssaMode {
+			fmt.Fprintln(os.Stderr, "deadcode: -whyLive requires -ssa")
+			os.Exit(2)
+		}
+		runWhyLive(patterns, *whyLive, cfg)
+		return
+	}
+
+	if *ssaMode {
+		runSSA(patterns, deadcode.Algorithm(*algo), cfg, out)
+		return
+	}
+
+	findings, err := deadcode.Analyze(patterns, deadcode.Options{IncludeExported: *includeExported, Config: cfg})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode:", err)
+		os.Exit(1)
+	}
+	printFindings(findings, out)
+}
+
+func runSSA(patterns []string, algo deadcode.Algorithm, cfg *deadcode.Config, out output) {
+	result, err := deadcode.AnalyzeSSA(patterns, algo, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode:", err)
+		os.Exit(1)
+	}
+	printFindings(result.Findings, out)
+}
+
+func runWhyLive(patterns []string, target string, cfg *deadcode.Config) {
+	graph, roots, allFuncs, err := deadcode.BuildWhyLiveGraph(patterns, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode:", err)
+		os.Exit(1)
+	}
+	fn, err := deadcode.FindFunction(allFuncs, target)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode:", err)
+		os.Exit(1)
+	}
+	path, err := deadcode.WhyLive(graph, roots, fn)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "deadcode:", err)
+		os.Exit(1)
+	}
+	fmt.Println(strings.Join(path, "\n"))
+}
+
+func printFindings(findings []deadcode.Finding, out output) {
+	switch {
+	case out.json:
+		enc := json.NewEncoder(os.Stdout)
+		for _, group := range deadcode.GroupByPackage(findings) {
+			if err := enc.Encode(group); err != nil {
+				fmt.Fprintln(os.Stderr, "deadcode:", err)
+				os.Exit(1)
+			}
+		}
+	case out.format != "":
+		tmpl, err := template.New("f").Parse(out.format)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "deadcode: -f:", err)
+			os.Exit(2)
+		}
+		for _, f := range findings {
+			if err := tmpl.Execute(os.Stdout, f); err != nil {
+				fmt.Fprintln(os.Stderr, "deadcode:", err)
+				os.Exit(1)
+			}
+			fmt.Println()
+		}
+	default:
+		for _, f := range findings {
+			fmt.Println(f)
+		}
+	}
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,72 @@
+package deadcode
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config declares project-specific liveness heuristics loaded from a
+// .deadcode.yaml file. Static reachability analysis can't see through
+// reflection, template method lookup, or registration side effects, so
+// Config lets a project tell the detector about these cases explicitly
+// rather than accepting the resulting false positives.
+type Config struct {
+	// AlwaysLive is a list of glob patterns (path.Match syntax) matched
+	// against a declaration's name as it appears in findings: the bare name
+	// for a package-level declaration, or "T.Method" ("*T.Method" for a
+	// pointer receiver) for a method in -ssa mode; any match is never
+	// reported. Typical entries: "*.MarshalJSON", "Test*".
+	AlwaysLive []string `yaml:"alwaysLive"`
+
+	// EntryPoints is a list of glob patterns (path.Match syntax) matched
+	// against a file's absolute path, as go/packages reports it; every
+	// package-level declaration in a matching file is treated as a
+	// reachability root, e.g. "/src/proj/cmd/*/main.go". A relative pattern
+	// such as "cmd/*/main.go" matches no file.
+	EntryPoints []string `yaml:"entryPoints"`
+
+	// DynamicCalls lists call targets whose arguments should be assumed
+	// to reach dynamic dispatch. Only "reflect.Value.Call" is currently
+	// implemented: any named type that flows into reflect.ValueOf has all
+	// of its exported methods marked live, via a simple type-flow pass in
+	// -ssa mode.
+	DynamicCalls []string `yaml:"dynamicCalls"`
+
+	// JSONTypes, when true, marks every exported method (e.g. MarshalJSON)
+	// of any named type passed to encoding/json's Marshal, MarshalIndent,
+	// or Encoder.Encode live in -ssa mode. The detector doesn't track
+	// individual struct fields, so this has no effect on fields.
+	JSONTypes bool `yaml:"jsonTypes"`
+}
+
+// LoadConfig reads and parses a .deadcode.yaml file. A missing file is not
+// an error; it yields a zero-value Config with every heuristic disabled.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// matchAny reports whether s matches any of patterns, using path.Match
+// semantics. A malformed pattern is treated as a non-match rather than an
+// error, since Config is user-authored YAML, not a compiled flag.
+func matchAny(patterns []string, s string) bool {
+	for _, pat := range patterns {
+		if ok, err := path.Match(pat, s); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
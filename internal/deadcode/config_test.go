@@ -0,0 +1,40 @@
+package deadcode
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".deadcode.yaml")
+	content := "alwaysLive:\n  - \"*.MarshalJSON\"\n  - \"Test*\"\njsonTypes: true\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if !cfg.JSONTypes {
+		t.Errorf("JSONTypes = false, want true")
+	}
+	if !matchAny(cfg.AlwaysLive, "TestFoo") {
+		t.Errorf("expected TestFoo to match alwaysLive patterns %v", cfg.AlwaysLive)
+	}
+	if matchAny(cfg.AlwaysLive, "Foo") {
+		t.Errorf("did not expect Foo to match alwaysLive patterns %v", cfg.AlwaysLive)
+	}
+}
+
+func TestLoadConfig_Missing(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.JSONTypes || len(cfg.AlwaysLive) != 0 {
+		t.Errorf("expected zero-value config for missing file, got %+v", cfg)
+	}
+}
@@ -0,0 +1,165 @@
+// Package deadcode finds package-level declarations that are never
+// referenced anywhere in the set of packages under analysis.
+package deadcode
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Kind identifies the category of a dead declaration.
+type Kind int
+
+const (
+	KindFunc Kind = iota
+	KindConst
+	KindType
+	KindVar
+)
+
+// String renders the kind the way findings are printed, e.g. "unused const".
+func (k Kind) String() string {
+	switch k {
+	case KindFunc:
+		return "unreachable func"
+	case KindConst:
+		return "unused const"
+	case KindType:
+		return "unused type"
+	case KindVar:
+		return "unused var"
+	default:
+		return "unknown"
+	}
+}
+
+// Finding is a single package-level declaration with no reference anywhere
+// in the analyzed package set.
+type Finding struct {
+	Kind     Kind
+	Name     string
+	Package  string
+	Pos      token.Position
+	Exported bool
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s %s.%s", f.Pos, f.Kind, f.Package, f.Name)
+}
+
+// Options controls what Analyze reports.
+type Options struct {
+	// IncludeExported reports exported identifiers of non-main packages too.
+	// By default they're suppressed, since they may be part of a public API
+	// consumed outside the analyzed package set.
+	IncludeExported bool
+
+	// Config, if non-nil, suppresses findings matched by its AlwaysLive
+	// and EntryPoints heuristics. See Config's doc comment.
+	Config *Config
+}
+
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+	packages.NeedSyntax | packages.NeedTypesInfo
+
+// Analyze loads the packages matching patterns and reports every
+// package-level func, const, var, and type declaration that is never used.
+func Analyze(patterns []string, opts Options) ([]Finding, error) {
+	pcfg := &packages.Config{Mode: loadMode}
+	pkgs, err := packages.Load(pcfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("errors loading %v", patterns)
+	}
+
+	// The used set legitimately needs the whole import graph: NeedDeps
+	// pulls in every dependency, and a use of a root-package object can
+	// only come from a package that imports it, which Visit will reach.
+	used := make(map[types.Object]bool)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.TypesInfo == nil {
+			return
+		}
+		for _, obj := range pkg.TypesInfo.Uses {
+			used[obj] = true
+		}
+	})
+
+	// Findings, in contrast, must be restricted to the packages the caller
+	// actually asked about: pkgs is exactly that root set, while Visit
+	// would also walk every transitive dependency (including the standard
+	// library) and report "dead code" inside packages the caller never
+	// asked to check.
+	var findings []Finding
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || pkg.Fset == nil {
+			continue
+		}
+		isMain := pkg.Name == "main"
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			kind, ok := kindOf(obj)
+			if !ok {
+				continue
+			}
+			if used[obj] {
+				continue
+			}
+			if obj.Exported() && !isMain && !opts.IncludeExported {
+				continue
+			}
+			if opts.Config != nil {
+				if matchAny(opts.Config.AlwaysLive, name) {
+					continue
+				}
+				if matchAny(opts.Config.EntryPoints, pkg.Fset.Position(obj.Pos()).Filename) {
+					continue
+				}
+			}
+			findings = append(findings, Finding{
+				Kind:     kind,
+				Name:     name,
+				Package:  pkg.PkgPath,
+				Pos:      pkg.Fset.Position(obj.Pos()),
+				Exported: obj.Exported(),
+			})
+		}
+	}
+	return findings, nil
+}
+
+// ObjectKind reports the Kind of a package-level object, or ok=false if
+// obj is not a declaration kind this analyzer tracks (e.g. an import
+// name). It's exported for callers, such as pkg/analyzer, that need to
+// classify a types.Object the same way Analyze does.
+func ObjectKind(obj types.Object) (Kind, bool) {
+	return kindOf(obj)
+}
+
+// kindOf reports the Kind of a package-level object, or ok=false if obj
+// is not a declaration kind this analyzer tracks (e.g. an import name).
+func kindOf(obj types.Object) (Kind, bool) {
+	switch o := obj.(type) {
+	case *types.Func:
+		// Exclude methods here; they're reached through their receiver's
+		// type and are only meaningfully "unused" in whole-program mode.
+		if sig, ok := o.Type().(*types.Signature); ok && sig.Recv() != nil {
+			return 0, false
+		}
+		return KindFunc, true
+	case *types.Const:
+		return KindConst, true
+	case *types.Var:
+		return KindVar, true
+	case *types.TypeName:
+		return KindType, true
+	}
+	return 0, false
+}
@@ -0,0 +1,77 @@
+package deadcode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyze_Fixture(t *testing.T) {
+	// pkg/utils is not a main package, so exported identifiers are
+	// suppressed by default; with IncludeExported, AppVersion and
+	// FormatName join the findings too, since nothing in this
+	// single-package fixture actually calls them. capitalize is the only
+	// declaration with a real caller (FormatName), so it's the only one
+	// that never shows up as a finding either way.
+	findings, err := Analyze([]string{"../../test/fixtures/go-project/pkg/utils"}, Options{IncludeExported: true})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	want := map[string]Kind{
+		"UnusedFunc":    KindFunc,
+		"UnusedConst":   KindConst,
+		"UnusedType":    KindType,
+		"internalState": KindVar,
+		"AppVersion":    KindConst,
+		"FormatName":    KindFunc,
+	}
+	got := make(map[string]Kind)
+	for _, f := range findings {
+		got[f.Name] = f.Kind
+	}
+
+	for name, kind := range want {
+		gotKind, ok := got[name]
+		if !ok {
+			t.Errorf("missing expected finding %q", name)
+			continue
+		}
+		if gotKind != kind {
+			t.Errorf("%s: got kind %v, want %v", name, gotKind, kind)
+		}
+	}
+
+	if _, ok := got["capitalize"]; ok {
+		t.Errorf("unexpected finding for capitalize, which is called by FormatName")
+	}
+
+	// Analyze must never report findings from packages outside the
+	// requested root set, e.g. standard-library dependencies pulled in by
+	// NeedDeps for building the used-objects set.
+	for _, f := range findings {
+		if !strings.HasSuffix(f.Package, "test/fixtures/go-project/pkg/utils") {
+			t.Errorf("finding %q reported for unexpected package %q", f.Name, f.Package)
+		}
+	}
+}
+
+func TestAnalyze_Fixture_SuppressesExportedByDefault(t *testing.T) {
+	findings, err := Analyze([]string{"../../test/fixtures/go-project/pkg/utils"}, Options{})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	got := make(map[string]Kind)
+	for _, f := range findings {
+		got[f.Name] = f.Kind
+	}
+
+	for _, name := range []string{"UnusedFunc", "UnusedConst", "UnusedType", "AppVersion", "FormatName"} {
+		if _, ok := got[name]; ok {
+			t.Errorf("exported dead identifier %q reported without IncludeExported", name)
+		}
+	}
+	if kind, ok := got["internalState"]; !ok || kind != KindVar {
+		t.Errorf("got %v, %v for internalState, want KindVar, true", kind, ok)
+	}
+}
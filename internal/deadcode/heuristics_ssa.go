@@ -0,0 +1,99 @@
+package deadcode
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// applyDynamicCallHeuristics extends live with functions that Config says
+// to assume reachable through dynamic dispatch that the call graph can't
+// see. It mutates live in place.
+func applyDynamicCallHeuristics(allFuncs map[*ssa.Function]bool, cfg *Config, live map[*ssa.Function]bool) {
+	if cfg == nil {
+		return
+	}
+	reflectCall := matchAny(cfg.DynamicCalls, "reflect.Value.Call")
+	jsonMarshal := cfg.JSONTypes
+
+	if !reflectCall && !jsonMarshal {
+		return
+	}
+
+	for fn := range allFuncs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				callee := call.Common().StaticCallee()
+				if callee == nil || callee.Pkg == nil {
+					continue
+				}
+				path := callee.Pkg.Pkg.Path()
+				switch {
+				case reflectCall && path == "reflect" && callee.Name() == "ValueOf":
+					markExportedMethodsLive(argType(call), allFuncs, live)
+				case jsonMarshal && path == "encoding/json" && isMarshalFunc(callee.Name()):
+					markExportedMethodsLive(argType(call), allFuncs, live)
+				}
+			}
+		}
+	}
+}
+
+func isMarshalFunc(name string) bool {
+	return name == "Marshal" || name == "MarshalIndent" || name == "Encode"
+}
+
+// argType returns the static type of a call's first argument after any
+// receiver, the value assumed to flow into reflection or serialization. An
+// argument converted to an interface yields its concrete type.
+func argType(call ssa.CallInstruction) types.Type {
+	args := call.Common().Args
+	if callee := call.Common().StaticCallee(); callee != nil && callee.Signature.Recv() != nil && len(args) > 0 {
+		args = args[1:] // skip the receiver, e.g. the *json.Encoder of Encode
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	v := args[0]
+	if mi, ok := v.(*ssa.MakeInterface); ok {
+		v = mi.X
+	}
+	return v.Type()
+}
+
+// markExportedMethodsLive marks every exported method of t (or, if t is a
+// pointer, of its element type) live, since reflection or serialization
+// can invoke them without a static call edge.
+func markExportedMethodsLive(t types.Type, allFuncs map[*ssa.Function]bool, live map[*ssa.Function]bool) {
+	if t == nil {
+		return
+	}
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return
+	}
+	for fn := range allFuncs {
+		recv := fn.Signature.Recv()
+		if recv == nil || fn.Object() == nil || !fn.Object().Exported() {
+			continue
+		}
+		if recvNamed(recv.Type()) == named {
+			live[fn] = true
+		}
+	}
+}
+
+func recvNamed(t types.Type) *types.Named {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, _ := t.(*types.Named)
+	return named
+}
@@ -0,0 +1,48 @@
+package deadcode
+
+import (
+	"sort"
+
+	"github.com/dev-eyoungmin/dead-code-detector/pkg/deadcodejson"
+)
+
+// jsonKind maps our internal Kind to the stable deadcodejson.Kind values.
+var jsonKind = map[Kind]deadcodejson.Kind{
+	KindFunc:  deadcodejson.KindFunc,
+	KindConst: deadcodejson.KindConst,
+	KindType:  deadcodejson.KindType,
+	KindVar:   deadcodejson.KindVar,
+}
+
+// JSON converts a Finding to its stable JSON representation.
+func (f Finding) JSON() deadcodejson.Finding {
+	return deadcodejson.Finding{
+		Kind:     jsonKind[f.Kind],
+		Name:     f.Package + "." + f.Name,
+		Package:  f.Package,
+		File:     f.Pos.Filename,
+		Line:     f.Pos.Line,
+		Col:      f.Pos.Column,
+		Exported: f.Exported,
+	}
+}
+
+// GroupByPackage groups findings by package import path, sorted by import
+// path, with findings inside each group kept in their original order.
+func GroupByPackage(findings []Finding) []deadcodejson.Package {
+	order := []string{}
+	byPkg := map[string][]deadcodejson.Finding{}
+	for _, f := range findings {
+		if _, ok := byPkg[f.Package]; !ok {
+			order = append(order, f.Package)
+		}
+		byPkg[f.Package] = append(byPkg[f.Package], f.JSON())
+	}
+	sort.Strings(order)
+
+	groups := make([]deadcodejson.Package, 0, len(order))
+	for _, pkg := range order {
+		groups = append(groups, deadcodejson.Package{ImportPath: pkg, Findings: byPkg[pkg]})
+	}
+	return groups
+}
@@ -0,0 +1,28 @@
+package deadcode
+
+import (
+	"go/token"
+	"testing"
+)
+
+func TestGroupByPackage(t *testing.T) {
+	findings := []Finding{
+		{Kind: KindFunc, Name: "B", Package: "b", Pos: token.Position{Filename: "b.go", Line: 1}},
+		{Kind: KindConst, Name: "A", Package: "a", Pos: token.Position{Filename: "a.go", Line: 2}},
+		{Kind: KindVar, Name: "A2", Package: "a", Pos: token.Position{Filename: "a.go", Line: 3}},
+	}
+
+	groups := GroupByPackage(findings)
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+	if groups[0].ImportPath != "a" || groups[1].ImportPath != "b" {
+		t.Fatalf("groups not sorted by import path: %+v", groups)
+	}
+	if len(groups[0].Findings) != 2 {
+		t.Fatalf("package a: got %d findings, want 2", len(groups[0].Findings))
+	}
+	if groups[0].Findings[0].Kind != "const" {
+		t.Errorf("got kind %q, want \"const\"", groups[0].Findings[0].Kind)
+	}
+}
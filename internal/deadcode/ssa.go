@@ -0,0 +1,285 @@
+package deadcode
+
+import (
+	"fmt"
+	"go/types"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/rta"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Algorithm selects the call-graph construction strategy used by AnalyzeSSA.
+type Algorithm string
+
+const (
+	// RTA (Rapid Type Analysis) is precise and the default; it requires the
+	// program's roots (main/init) to be known up front.
+	RTA Algorithm = "rta"
+	// VTA (Variable Type Analysis) is more conservative but scales better
+	// and doesn't need call-graph roots to seed devirtualization.
+	VTA Algorithm = "vta"
+)
+
+const ssaLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+	packages.NeedSyntax | packages.NeedTypesInfo
+
+// SSAResult is the outcome of a whole-program AnalyzeSSA run: the call graph
+// that was built, and the set of funcs in it considered dead.
+type SSAResult struct {
+	Graph    *callgraph.Graph
+	Findings []Finding
+}
+
+// AnalyzeSSA builds a whole-program SSA call graph rooted at the main
+// package's main and init functions and reports every named function or
+// method that the graph never reaches. Unlike Analyze, this mode
+// devirtualizes interface calls: a concrete method is live if its receiver
+// type is ever used to satisfy an interface whose method is called. If cfg
+// is non-nil, its DynamicCalls and JSONTypes heuristics additionally mark
+// functions live that the call graph alone would miss (see Config).
+func AnalyzeSSA(patterns []string, algo Algorithm, cfg *Config) (*SSAResult, error) {
+	prog, rootPkgs, err := loadSSAProgram(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	allFuncs := ssautil.AllFunctions(prog)
+	roots := rootFunctions(prog)
+	roots = append(roots, entryPointFunctions(prog, allFuncs, cfg)...)
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no main package with func main found in %v", patterns)
+	}
+
+	var cg *callgraph.Graph
+	switch algo {
+	case VTA:
+		base := cha.CallGraph(prog)
+		cg = vta.CallGraph(allFuncs, base)
+	case RTA, "":
+		res := rta.Analyze(roots, true)
+		cg = res.CallGraph
+	default:
+		return nil, fmt.Errorf("unknown algorithm %q", algo)
+	}
+	cg.DeleteSyntheticNodes()
+
+	live := reachableFuncs(cg, roots)
+	applyDynamicCallHeuristics(allFuncs, cfg, live)
+
+	var findings []Finding
+	for fn := range allFuncs {
+		if fn.Synthetic != "" || fn.Name() == "init" || fn.Pkg == nil {
+			continue
+		}
+		if !rootPkgs[fn.Pkg.Pkg.Path()] {
+			// Whole-program reachability necessarily traverses the
+			// standard library and every dependency; only report findings
+			// for the packages the caller actually asked to check.
+			continue
+		}
+		if live[fn] {
+			continue
+		}
+		obj := fn.Object()
+		if obj == nil {
+			continue
+		}
+		if cfg != nil && matchAny(cfg.AlwaysLive, funcDisplayName(fn)) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Kind:     KindFunc,
+			Name:     funcDisplayName(fn),
+			Package:  fn.Pkg.Pkg.Path(),
+			Pos:      prog.Fset.Position(obj.Pos()),
+			Exported: obj.Exported(),
+		})
+	}
+	return &SSAResult{Graph: cg, Findings: findings}, nil
+}
+
+// WhyLive returns the shortest chain of call edges in cg from one of roots
+// to target, formatted one call per line, for the -whyLive debugging flag.
+func WhyLive(cg *callgraph.Graph, roots []*ssa.Function, target *ssa.Function) ([]string, error) {
+	targetNode := cg.Nodes[target]
+	if targetNode == nil {
+		return nil, fmt.Errorf("%s is not in the call graph", target)
+	}
+
+	type step struct {
+		node *callgraph.Node
+		edge *callgraph.Edge
+		from *step
+	}
+	visited := map[*callgraph.Node]bool{}
+	queue := []*step{}
+	for _, r := range roots {
+		if n := cg.Nodes[r]; n != nil && !visited[n] {
+			visited[n] = true
+			queue = append(queue, &step{node: n})
+		}
+	}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur.node == targetNode {
+			var lines []string
+			for s := cur; s.from != nil; s = s.from {
+				lines = append([]string{describeEdge(s.edge)}, lines...)
+			}
+			return lines, nil
+		}
+		for _, e := range cur.node.Out {
+			if visited[e.Callee] {
+				continue
+			}
+			visited[e.Callee] = true
+			queue = append(queue, &step{node: e.Callee, edge: e, from: cur})
+		}
+	}
+	return nil, fmt.Errorf("%s is unreachable from the analyzed roots", target)
+}
+
+func describeEdge(e *callgraph.Edge) string {
+	return fmt.Sprintf("%s -> %s", e.Caller.Func, e.Callee.Func)
+}
+
+// loadSSAProgram builds the whole-program SSA form needed for reachability
+// analysis, plus the set of import paths packages.Load resolved directly
+// from patterns (as opposed to the transitive dependencies NeedDeps also
+// pulls in), so callers can tell "the user's code" apart from the rest of
+// the program.
+func loadSSAProgram(patterns []string) (prog *ssa.Program, rootPkgs map[string]bool, err error) {
+	cfg := &packages.Config{Mode: ssaLoadMode}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading packages: %w", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, nil, fmt.Errorf("errors loading %v", patterns)
+	}
+
+	rootPkgs = make(map[string]bool, len(pkgs))
+	for _, pkg := range pkgs {
+		rootPkgs[pkg.PkgPath] = true
+	}
+
+	prog, _ = ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+	return prog, rootPkgs, nil
+}
+
+// rootFunctions returns the main and init functions of every main package in
+// prog, the standard roots for whole-program reachability.
+func rootFunctions(prog *ssa.Program) []*ssa.Function {
+	var roots []*ssa.Function
+	for _, mainPkg := range ssautil.MainPackages(prog.AllPackages()) {
+		if fn := mainPkg.Func("main"); fn != nil {
+			roots = append(roots, fn)
+		}
+		if fn := mainPkg.Func("init"); fn != nil {
+			roots = append(roots, fn)
+		}
+	}
+	return roots
+}
+
+// entryPointFunctions returns the funcs in allFuncs defined in a file
+// matching one of cfg's EntryPoints patterns, treated as extra reachability
+// roots alongside main and init.
+func entryPointFunctions(prog *ssa.Program, allFuncs map[*ssa.Function]bool, cfg *Config) []*ssa.Function {
+	if cfg == nil || len(cfg.EntryPoints) == 0 {
+		return nil
+	}
+	var extra []*ssa.Function
+	for fn := range allFuncs {
+		if fn.Object() == nil {
+			continue
+		}
+		file := prog.Fset.Position(fn.Object().Pos()).Filename
+		if matchAny(cfg.EntryPoints, file) {
+			extra = append(extra, fn)
+		}
+	}
+	return extra
+}
+
+func reachableFuncs(cg *callgraph.Graph, roots []*ssa.Function) map[*ssa.Function]bool {
+	live := make(map[*ssa.Function]bool)
+	var queue []*callgraph.Node
+	for _, r := range roots {
+		if n := cg.Nodes[r]; n != nil && !live[r] {
+			live[r] = true
+			queue = append(queue, n)
+		}
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, e := range n.Out {
+			if fn := e.Callee.Func; fn != nil && !live[fn] {
+				live[fn] = true
+				queue = append(queue, e.Callee)
+			}
+		}
+	}
+	return live
+}
+
+// BuildWhyLiveGraph loads patterns and builds the default (RTA) call graph
+// used by the -whyLive flag, along with its roots and the full function set
+// needed to resolve a "pkg.Func" name to an *ssa.Function.
+func BuildWhyLiveGraph(patterns []string, cfg *Config) (*callgraph.Graph, []*ssa.Function, map[*ssa.Function]bool, error) {
+	prog, _, err := loadSSAProgram(patterns)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	allFuncs := ssautil.AllFunctions(prog)
+	roots := rootFunctions(prog)
+	roots = append(roots, entryPointFunctions(prog, allFuncs, cfg)...)
+	if len(roots) == 0 {
+		return nil, nil, nil, fmt.Errorf("no main package with func main found in %v", patterns)
+	}
+	res := rta.Analyze(roots, true)
+	res.CallGraph.DeleteSyntheticNodes()
+	return res.CallGraph, roots, allFuncs, nil
+}
+
+// FindFunction looks up a function in allFuncs by its qualified name, as
+// printed in Finding.String or passed to the -whyLive flag (e.g.
+// "pkg.Func" or "pkg.Type.Method").
+func FindFunction(allFuncs map[*ssa.Function]bool, qualifiedName string) (*ssa.Function, error) {
+	for fn := range allFuncs {
+		if fn.Pkg == nil {
+			continue
+		}
+		if fmt.Sprintf("%s.%s", fn.Pkg.Pkg.Path(), funcDisplayName(fn)) == qualifiedName {
+			return fn, nil
+		}
+	}
+	return nil, fmt.Errorf("no function named %q found", qualifiedName)
+}
+
+func funcDisplayName(fn *ssa.Function) string {
+	if recv := fn.Signature.Recv(); recv != nil {
+		return fmt.Sprintf("%s.%s", recvTypeName(recv.Type()), fn.Name())
+	}
+	return fn.Name()
+}
+
+func recvTypeName(t types.Type) string {
+	if p, ok := t.(*types.Pointer); ok {
+		return "*" + recvTypeName(p.Elem())
+	}
+	if n, ok := t.(*types.Named); ok {
+		return n.Obj().Name()
+	}
+	return t.String()
+}
@@ -0,0 +1,40 @@
+package deadcode
+
+import "testing"
+
+func TestAnalyzeSSA_Fixture(t *testing.T) {
+	const rootPkg = "github.com/dev-eyoungmin/dead-code-detector/test/fixtures/ssa-project"
+
+	result, err := AnalyzeSSA([]string{"../../test/fixtures/ssa-project"}, RTA, nil)
+	if err != nil {
+		t.Fatalf("AnalyzeSSA: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, f := range result.Findings {
+		got[f.Name] = true
+		if f.Package != rootPkg {
+			t.Errorf("finding %q reported for unexpected package %q", f.Name, f.Package)
+		}
+	}
+
+	if got["myString.String"] {
+		t.Errorf("myString.String is reachable via fmt.Stringer devirtualization; must not be reported dead")
+	}
+	if !got["unusedHelper"] {
+		t.Errorf("expected unusedHelper to be reported dead, got %v", result.Findings)
+	}
+}
+
+func TestAnalyzeSSA_ConfigAlwaysLive(t *testing.T) {
+	cfg := &Config{AlwaysLive: []string{"unusedHelper"}}
+	result, err := AnalyzeSSA([]string{"../../test/fixtures/ssa-project"}, RTA, cfg)
+	if err != nil {
+		t.Fatalf("AnalyzeSSA: %v", err)
+	}
+	for _, f := range result.Findings {
+		if f.Name == "unusedHelper" {
+			t.Errorf("unusedHelper matched an AlwaysLive pattern and should have been suppressed")
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Package fix rewrites Go source files to remove declarations reported
+// dead by the deadcode analyzer, preserving surrounding comments and
+// formatting.
+package fix
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/imports"
+
+	"github.com/dev-eyoungmin/dead-code-detector/internal/deadcode"
+)
+
+var generatedCodeRE = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// Options controls which files Apply is allowed to rewrite.
+type Options struct {
+	IncludeTests bool // rewrite _test.go files too
+	Diff         bool // don't write files; just compute before/after for Diff
+}
+
+// FileChange is the result of considering one source file for fixes.
+type FileChange struct {
+	Path    string
+	Before  []byte
+	After   []byte
+	Skipped bool
+	Reason  string // set when Skipped
+}
+
+// Apply removes the declarations named by findings from their source
+// files, grouping findings by file so each file is parsed once.
+//
+// As a safety net independent of whatever scope the findings were
+// produced with, Apply refuses to touch any file outside the current
+// module: a scoping bug (or a future caller) that hands it findings from
+// the standard library or the module cache must not result in this tool
+// rewriting files it doesn't own.
+func Apply(findings []deadcode.Finding, opts Options) ([]FileChange, error) {
+	root, err := moduleRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	var order []string
+	byFile := map[string][]deadcode.Finding{}
+	for _, f := range findings {
+		if _, ok := byFile[f.Pos.Filename]; !ok {
+			order = append(order, f.Pos.Filename)
+		}
+		byFile[f.Pos.Filename] = append(byFile[f.Pos.Filename], f)
+	}
+
+	changes := make([]FileChange, 0, len(order))
+	for _, path := range order {
+		change, err := applyFile(path, byFile[path], root, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func applyFile(path string, findings []deadcode.Finding, root string, opts Options) (FileChange, error) {
+	if !underRoot(root, path) {
+		return FileChange{Path: path, Skipped: true, Reason: fmt.Sprintf("outside module root %s, refusing to rewrite", root)}, nil
+	}
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return FileChange{}, err
+	}
+
+	if !opts.IncludeTests && strings.HasSuffix(path, "_test.go") {
+		return FileChange{Path: path, Before: before, After: before, Skipped: true, Reason: "test file (pass -tests to include)"}, nil
+	}
+	if generatedCodeRE.Match(before) {
+		return FileChange{Path: path, Before: before, After: before, Skipped: true, Reason: "generated file"}, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, before, parser.ParseComments)
+	if err != nil {
+		return FileChange{}, err
+	}
+
+	dead := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		dead[f.Name] = true
+	}
+	removeDeadDecls(file, dead)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return FileChange{}, err
+	}
+
+	after, err := imports.Process(path, buf.Bytes(), nil)
+	if err != nil {
+		// A goimports hiccup shouldn't block the deletion; fall back to the
+		// unpruned-imports output rather than failing the whole run.
+		after = buf.Bytes()
+	}
+
+	if !opts.Diff {
+		if err := os.WriteFile(path, after, 0o644); err != nil {
+			return FileChange{}, err
+		}
+	}
+	return FileChange{Path: path, Before: before, After: after}, nil
+}
+
+// moduleRoot walks up from the working directory to find the nearest
+// go.mod, the boundary Apply refuses to rewrite files outside of.
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no go.mod found above %s", dir)
+		}
+		dir = parent
+	}
+}
+
+// underRoot reports whether path is contained in root.
+func underRoot(root, path string) bool {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absRoot, absPath)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// removeDeadDecls drops FuncDecls and GenDecl specs whose name is in dead,
+// trimming a spec out of a multi-spec const/var/type block rather than
+// deleting the whole block when siblings survive. It also strips each
+// removed decl/spec's own Doc and trailing Comment from file.Comments, so
+// format.Node doesn't print the dead code's documentation as an orphaned
+// comment with nothing left under it.
+func removeDeadDecls(file *ast.File, dead map[string]bool) {
+	var removed []posRange
+	kept := make([]ast.Decl, 0, len(file.Decls))
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil && dead[d.Name.Name] {
+				removed = append(removed, rangeOf(d.Doc, nil, d))
+				continue
+			}
+			kept = append(kept, d)
+		case *ast.GenDecl:
+			specs := make([]ast.Spec, 0, len(d.Specs))
+			for _, spec := range d.Specs {
+				if specIsDead(spec, dead) {
+					doc, comment := specComments(spec)
+					removed = append(removed, rangeOf(doc, comment, spec))
+					continue
+				}
+				specs = append(specs, spec)
+			}
+			if len(specs) == 0 {
+				removed = append(removed, rangeOf(d.Doc, nil, d))
+				continue
+			}
+			d.Specs = specs
+			kept = append(kept, d)
+		default:
+			kept = append(kept, decl)
+		}
+	}
+	file.Decls = kept
+	file.Comments = filterComments(file.Comments, removed)
+}
+
+// posRange is a half-open [Start, End) span of source positions.
+type posRange struct {
+	start, end token.Pos
+}
+
+// rangeOf returns the span covering node, extended to include doc (which
+// precedes node) and a trailing same-line comment, if either is present.
+func rangeOf(doc, comment *ast.CommentGroup, node interface {
+	Pos() token.Pos
+	End() token.Pos
+}) posRange {
+	r := posRange{node.Pos(), node.End()}
+	if doc != nil {
+		r.start = doc.Pos()
+	}
+	if comment != nil && comment.End() > r.end {
+		r.end = comment.End()
+	}
+	return r
+}
+
+// specComments returns the Doc and trailing Comment of a ValueSpec or
+// TypeSpec, the two Spec kinds removeDeadDecls ever removes individually.
+func specComments(spec ast.Spec) (doc, comment *ast.CommentGroup) {
+	switch s := spec.(type) {
+	case *ast.ValueSpec:
+		return s.Doc, s.Comment
+	case *ast.TypeSpec:
+		return s.Doc, s.Comment
+	}
+	return nil, nil
+}
+
+// filterComments drops every comment group that falls inside one of the
+// removed spans, leaving the rest of file.Comments (which format.Node also
+// uses to place comments unrelated to any decl) untouched.
+func filterComments(groups []*ast.CommentGroup, removed []posRange) []*ast.CommentGroup {
+	if len(removed) == 0 {
+		return groups
+	}
+	kept := make([]*ast.CommentGroup, 0, len(groups))
+	for _, g := range groups {
+		dead := false
+		for _, r := range removed {
+			if g.Pos() >= r.start && g.Pos() < r.end {
+				dead = true
+				break
+			}
+		}
+		if !dead {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
+
+// specIsDead reports whether every name declared by spec is dead. A
+// ValueSpec with a shared initializer (`var a, b = f()`) is only removed
+// if none of a, b survive; partial removal would leave a broken call.
+func specIsDead(spec ast.Spec, dead map[string]bool) bool {
+	switch s := spec.(type) {
+	case *ast.ValueSpec:
+		if len(s.Names) == 0 {
+			return false
+		}
+		for _, name := range s.Names {
+			if !dead[name.Name] {
+				return false
+			}
+		}
+		return true
+	case *ast.TypeSpec:
+		return dead[s.Name.Name]
+	}
+	return false
+}
+
+// Diff returns a unified diff between a file's before and after contents,
+// shelling out to the system diff(1), the same way gofmt -d does.
+func Diff(path string, before, after []byte) (string, error) {
+	if bytes.Equal(before, after) {
+		return "", nil
+	}
+
+	beforeFile, err := os.CreateTemp("", "deadcode-fix-before-*.go")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(beforeFile.Name())
+	afterFile, err := os.CreateTemp("", "deadcode-fix-after-*.go")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(afterFile.Name())
+
+	if _, err := beforeFile.Write(before); err != nil {
+		return "", err
+	}
+	if _, err := afterFile.Write(after); err != nil {
+		return "", err
+	}
+	beforeFile.Close()
+	afterFile.Close()
+
+	out, err := exec.Command("diff", "-u", beforeFile.Name(), afterFile.Name()).CombinedOutput()
+	if err != nil && len(out) == 0 {
+		// diff(1) exits 1 merely because the files differ; only a truly
+		// empty failure (e.g. binary missing) is a real error.
+		return "", err
+	}
+
+	report := string(out)
+	report = strings.Replace(report, beforeFile.Name(), path+".orig", 1)
+	report = strings.Replace(report, afterFile.Name(), path, 1)
+	return report, nil
+}
@@ -0,0 +1,134 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dev-eyoungmin/dead-code-detector/internal/deadcode"
+)
+
+func TestApply_RemovesDeadDecls(t *testing.T) {
+	src, err := os.ReadFile("../../test/fixtures/go-project/pkg/utils/utils.go")
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+
+	// Apply refuses to rewrite anything outside the current module, so the
+	// scratch copy has to live under the module root rather than under
+	// the system temp directory t.TempDir() would otherwise use.
+	dir, err := os.MkdirTemp("../../test/fixtures", "fix-test-*")
+	if err != nil {
+		t.Fatalf("MkdirTemp: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "utils.go")
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		t.Fatalf("writing copy: %v", err)
+	}
+
+	findings := []deadcode.Finding{
+		{Kind: deadcode.KindConst, Name: "UnusedConst"},
+		{Kind: deadcode.KindFunc, Name: "UnusedFunc"},
+		{Kind: deadcode.KindVar, Name: "internalState"},
+	}
+	for i := range findings {
+		findings[i].Pos.Filename = path
+	}
+
+	changes, err := Apply(findings, Options{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(changes))
+	}
+
+	after := string(changes[0].After)
+	for _, want := range []string{"UnusedConst", "UnusedFunc", "internalState"} {
+		if strings.Contains(after, want) {
+			t.Errorf("output still contains dead declaration %q:\n%s", want, after)
+		}
+	}
+	for _, want := range []string{"AppVersion", "FormatName", "capitalize"} {
+		if !strings.Contains(after, want) {
+			t.Errorf("output missing live declaration %q:\n%s", want, after)
+		}
+	}
+}
+
+func TestApply_RemovesDocCommentsWithTheirDecl(t *testing.T) {
+	src, err := os.ReadFile("../../test/fixtures/fix-comments/commented.go")
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+
+	dir, err := os.MkdirTemp("../../test/fixtures", "fix-test-*")
+	if err != nil {
+		t.Fatalf("MkdirTemp: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "commented.go")
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		t.Fatalf("writing copy: %v", err)
+	}
+
+	findings := []deadcode.Finding{
+		{Kind: deadcode.KindConst, Name: "Dead"},
+		{Kind: deadcode.KindFunc, Name: "UnusedFunc"},
+	}
+	for i := range findings {
+		findings[i].Pos.Filename = path
+	}
+
+	changes, err := Apply(findings, Options{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(changes))
+	}
+
+	after := string(changes[0].After)
+	for _, want := range []string{"Dead is removed.", "UnusedFunc is never called.", "Dead", "UnusedFunc"} {
+		if strings.Contains(after, want) {
+			t.Errorf("output still contains removed declaration or its doc comment %q:\n%s", want, after)
+		}
+	}
+	for _, want := range []string{"Used is kept.", "Used = 1", "UsedFunc is called by init so it survives."} {
+		if !strings.Contains(after, want) {
+			t.Errorf("output missing live declaration or its doc comment %q:\n%s", want, after)
+		}
+	}
+}
+
+func TestApply_SkipsFilesOutsideModule(t *testing.T) {
+	dir := t.TempDir() // outside the module root, e.g. the module cache or GOROOT
+	path := filepath.Join(dir, "outside.go")
+	src := []byte("package outside\n\nfunc Dead() {}\n")
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		t.Fatalf("writing file: %v", err)
+	}
+
+	findings := []deadcode.Finding{{Kind: deadcode.KindFunc, Name: "Dead"}}
+	findings[0].Pos.Filename = path
+
+	changes, err := Apply(findings, Options{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(changes) != 1 || !changes[0].Skipped {
+		t.Fatalf("got %+v, want a single skipped change", changes)
+	}
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading file: %v", err)
+	}
+	if string(after) != string(src) {
+		t.Errorf("file outside the module was rewritten:\n%s", after)
+	}
+}
@@ -0,0 +1,89 @@
+// Package analyzer exposes the dead-code detector as a
+// golang.org/x/tools/go/analysis.Analyzer, so it can be loaded by
+// golangci-lint (via its module-plugin system) or run with
+// singlechecker/multichecker.
+//
+// Unlike the standalone deadcode binary, an analysis.Pass only sees one
+// package at a time, so whole-program reachability isn't available here.
+// This analyzer therefore runs in a conservative per-package mode: it only
+// flags unexported declarations with no use anywhere in the package being
+// analyzed. That's the mode most linter frameworks can support. Prefer the
+// standalone binary (deadcode -ssa) when you can run it over the whole
+// program and want real reachability, including through interfaces
+// implemented in other packages.
+package analyzer
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/dev-eyoungmin/dead-code-detector/internal/deadcode"
+)
+
+// Analyzer reports unexported package-level funcs, consts, vars, and types
+// with no use within the package, plus unexported methods never called or
+// devirtualized to by an interface call within the package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "deadcode",
+	Doc:      "report unexported declarations with no use in the current package (conservative, per-package)",
+	Requires: []*analysis.Analyzer{inspect.Analyzer, buildssa.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	used := make(map[types.Object]bool, len(pass.TypesInfo.Uses))
+	for _, obj := range pass.TypesInfo.Uses {
+		used[obj] = true
+	}
+
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if obj.Exported() || used[obj] {
+			continue
+		}
+		kind, ok := deadcode.ObjectKind(obj)
+		if !ok {
+			continue
+		}
+		pass.Reportf(obj.Pos(), "%s %s is unused", kind, name)
+	}
+
+	reportUnusedMethods(pass, used)
+	return nil, nil
+}
+
+// reportUnusedMethods flags unexported methods that are neither called
+// directly nor reachable through an interface dispatch within the
+// package. Direct calls already show up in pass.TypesInfo.Uses, but a
+// call through an interface resolves to the interface's method, not the
+// concrete one, so we devirtualize with a local (per-package) CHA call
+// graph built from the package's own SSA form.
+func reportUnusedMethods(pass *analysis.Pass, directlyUsed map[types.Object]bool) {
+	ssaInfo := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	cg := cha.CallGraph(ssaInfo.Pkg.Prog)
+
+	called := make(map[*ssa.Function]bool)
+	for fn, node := range cg.Nodes {
+		if len(node.In) > 0 {
+			called[fn] = true
+		}
+	}
+
+	for _, fn := range ssaInfo.SrcFuncs {
+		recv := fn.Signature.Recv()
+		if recv == nil || fn.Object() == nil {
+			continue
+		}
+		obj := fn.Object()
+		if obj.Exported() || directlyUsed[obj] || called[fn] {
+			continue
+		}
+		pass.Reportf(obj.Pos(), "method %s is unused", fn.Name())
+	}
+}
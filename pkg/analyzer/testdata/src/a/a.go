@@ -0,0 +1,12 @@
+package a
+
+const used = 1
+const unusedConst = 2 // want `const unusedConst is unused`
+
+func Exported() int {
+	return used
+}
+
+func unusedFunc() int { // want `func unusedFunc is unused`
+	return 0
+}
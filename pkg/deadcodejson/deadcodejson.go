@@ -0,0 +1,35 @@
+// Package deadcodejson defines the stable JSON schema emitted by
+// `deadcode -json`, so editors, CI, and other tooling can unmarshal
+// findings without redefining these types themselves.
+package deadcodejson
+
+// Kind identifies the category of a dead declaration.
+type Kind string
+
+const (
+	KindFunc  Kind = "func"
+	KindConst Kind = "const"
+	KindType  Kind = "type"
+	KindVar   Kind = "var"
+)
+
+// Finding is the JSON representation of a single dead declaration.
+type Finding struct {
+	Kind Kind `json:"kind"`
+	// Name is the fully qualified name: "pkg.Name" for a package-level
+	// declaration, or "pkg.T.Method" ("pkg.*T.Method" for a pointer
+	// receiver) for a method, where pkg is the import path.
+	Name     string `json:"name"`
+	Package  string `json:"package"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Col      int    `json:"col"`
+	Exported bool   `json:"exported"`
+}
+
+// Package groups every finding reported for a single package, identified
+// by its import path.
+type Package struct {
+	ImportPath string    `json:"importPath"`
+	Findings   []Finding `json:"findings"`
+}
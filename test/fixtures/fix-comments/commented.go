@@ -0,0 +1,22 @@
+// Package commented is a fixture for Apply's comment-stripping behaviour:
+// every declaration below except init carries a Doc comment, so a fix that only drops
+// the AST node and forgets its Doc would leave orphaned comments behind.
+package commented
+
+const (
+	// Used is kept.
+	Used = 1
+	// Dead is removed.
+	Dead = 2
+)
+
+// UnusedFunc is never called.
+func UnusedFunc() {}
+
+// UsedFunc is called by init so it survives.
+func UsedFunc() {}
+
+func init() {
+	UsedFunc()
+	_ = Used
+}
@@ -22,3 +22,7 @@ func capitalize(s string) string {
 }
 
 var internalState = 0
+
+type UnusedType struct {
+	Field string
+}
@@ -0,0 +1,25 @@
+// Package main is a fixture for AnalyzeSSA: myString.String is only
+// called through the fmt.Stringer interface, so a purely syntactic
+// analysis would miss that it's reachable, while unusedHelper is never
+// called at all, directly or otherwise.
+package main
+
+import "fmt"
+
+type myString string
+
+func (s myString) String() string {
+	return "my:" + string(s)
+}
+
+func printStringer(s fmt.Stringer) {
+	fmt.Println(s.String())
+}
+
+func unusedHelper() string {
+	return "nobody calls me"
+}
+
+func main() {
+	printStringer(myString("hello"))
+}